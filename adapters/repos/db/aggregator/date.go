@@ -28,6 +28,8 @@ func addDateAggregations(prop *aggregation.Property,
 		prop.DateAggregations = map[string]interface{}{}
 	}
 
+	agg.prepareFor(aggs)
+
 	for _, aProp := range aggs {
 		switch aProp {
 		case aggregation.MinimumAggregator:
@@ -40,6 +42,12 @@ func addDateAggregations(prop *aggregation.Property,
 			prop.DateAggregations[aProp.String()] = agg.Count()
 		case aggregation.MedianAggregator:
 			prop.DateAggregations[aProp.String()] = agg.Median()
+		case aggregation.PercentileAggregator:
+			percentiles := make(map[string]interface{}, len(prop.Percentiles))
+			for _, q := range prop.Percentiles {
+				percentiles[formatPercentileKey(q)] = agg.Percentile(q)
+			}
+			prop.DateAggregations[aProp.String()] = percentiles
 
 		default:
 			continue
@@ -55,12 +63,15 @@ type dateAggregator struct {
 	mode         timestamp
 	pairs        []timestampCountPair // for row-based median calculation
 	valueCounter map[timestamp]uint64 // for individual median calculation
+	hist         *percentileHistogram // sparse bucket counts for Percentile()
+	prepared     bool                 // whether prepareFor() has already run
 }
 
 func newDateAggregator() *dateAggregator {
 	return &dateAggregator{
 		min:          timestamp{epochNano: math.MaxInt64},
 		valueCounter: map[timestamp]uint64{},
+		hist:         newPercentileHistogram(),
 	}
 }
 
@@ -108,6 +119,8 @@ func (a *dateAggregator) AddTimestamp(rfc3339 string) error {
 	count++
 	a.valueCounter[ts] = count
 
+	a.hist.Add(ts.epochNano, 1)
+
 	return nil
 }
 
@@ -142,6 +155,7 @@ func (a *dateAggregator) addRow(ts timestamp, count uint64) error {
 	}
 
 	a.pairs = append(a.pairs, timestampCountPair{value: ts, count: count})
+	a.hist.Add(ts.epochNano, count)
 
 	return nil
 }
@@ -154,8 +168,9 @@ func (a *dateAggregator) Min() string {
 	return a.min.rfc3339
 }
 
-// Mode does not require preparation if build from rows, but requires a call of
-// buildPairsFromCounts() if it was built using individual objects
+// Mode does not require preparation if built from rows. If built from
+// individual objects, the caller must go through prepareFor() first (which
+// addDateAggregations already does) so buildPairsFromCounts() has run.
 func (a *dateAggregator) Mode() string {
 	return a.mode.rfc3339
 }
@@ -164,8 +179,9 @@ func (a *dateAggregator) Count() int64 {
 	return int64(a.count)
 }
 
-// Median does not require preparation if build from rows, but requires a call of
-// buildPairsFromCounts() if it was built using individual objects
+// Median does not require preparation if built from rows. If built from
+// individual objects, the caller must go through prepareFor() first (which
+// addDateAggregations already does) so buildPairsFromCounts() has run.
 func (a *dateAggregator) Median() string {
 	var index uint64
 	if a.count%2 == 0 {
@@ -188,6 +204,47 @@ func (a *dateAggregator) Median() string {
 	return median.rfc3339
 }
 
+// Percentile returns the timestamp at quantile q (0..1) without ever
+// materializing the individual pairs: it walks the sparse histogram built up
+// incrementally by AddTimestamp/addRow and interpolates within the winning
+// bucket, so memory stays O(log range) regardless of how many distinct
+// timestamps were seen.
+func (a *dateAggregator) Percentile(q float64) string {
+	nsec := a.hist.Quantile(a.count, q)
+	return time.Unix(0, nsec).UTC().Format(time.RFC3339Nano)
+}
+
+func formatPercentileKey(q float64) string {
+	return fmt.Sprintf("p%g", q*100)
+}
+
+// prepareFor lazily materializes and sorts a.pairs, but only when the
+// requested aggregators actually need ordering (Median, and Mode if it was
+// built from individual objects rather than rows). Min/Max/Count stay O(1)
+// per row even on result sets with millions of distinct timestamps, since
+// they never trigger the sort below.
+func (a *dateAggregator) prepareFor(aggs []aggregation.Aggregator) {
+	if a.prepared {
+		return
+	}
+
+	if len(a.valueCounter) == 0 {
+		// built from rows: Mode/maxCount were already tracked incrementally in
+		// addRow, and pairs (if any) arrive pre-sorted from the inverted index
+		a.prepared = true
+		return
+	}
+
+	for _, aProp := range aggs {
+		if aProp == aggregation.MedianAggregator || aProp == aggregation.ModeAggregator {
+			a.buildPairsFromCounts()
+			break
+		}
+	}
+
+	a.prepared = true
+}
+
 // turns the value counter into a sorted list, as well as identifying the mode
 func (a *dateAggregator) buildPairsFromCounts() {
 	for value, count := range a.valueCounter {
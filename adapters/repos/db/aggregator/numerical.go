@@ -0,0 +1,236 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package aggregator
+
+import (
+	"math"
+	"sort"
+
+	"github.com/semi-technologies/weaviate/entities/aggregation"
+)
+
+func addNumericalAggregations(prop *aggregation.Property,
+	aggs []aggregation.Aggregator, agg *numericAggregator) {
+	if prop.NumericalAggregations == nil {
+		prop.NumericalAggregations = map[string]interface{}{}
+	}
+
+	agg.prepareFor(aggs)
+
+	for _, aProp := range aggs {
+		switch aProp {
+		case aggregation.MinimumAggregator:
+			prop.NumericalAggregations[aProp.String()] = agg.Min()
+		case aggregation.MaximumAggregator:
+			prop.NumericalAggregations[aProp.String()] = agg.Max()
+		case aggregation.ModeAggregator:
+			prop.NumericalAggregations[aProp.String()] = agg.Mode()
+		case aggregation.CountAggregator:
+			prop.NumericalAggregations[aProp.String()] = agg.Count()
+		case aggregation.MedianAggregator:
+			prop.NumericalAggregations[aProp.String()] = agg.Median()
+		case aggregation.PercentileAggregator:
+			percentiles := make(map[string]interface{}, len(prop.Percentiles))
+			for _, q := range prop.Percentiles {
+				percentiles[formatPercentileKey(q)] = agg.Percentile(q)
+			}
+			prop.NumericalAggregations[aProp.String()] = percentiles
+
+		default:
+			continue
+		}
+	}
+}
+
+// numericAggregator tracks the same statistics as dateAggregator, but over
+// a float64 value axis rather than a timestamp one.
+type numericAggregator struct {
+	count        uint64
+	maxCount     uint64
+	min          float64
+	max          float64
+	mode         float64
+	pairs        []numberCountPair  // for row-based median calculation
+	valueCounter map[float64]uint64 // for individual median calculation
+	hist         *percentileHistogram
+	prepared     bool // whether prepareFor() has already run
+}
+
+func newNumericAggregator() *numericAggregator {
+	return &numericAggregator{
+		min:          math.MaxFloat64,
+		valueCounter: map[float64]uint64{},
+		hist:         newPercentileHistogram(),
+	}
+}
+
+type numberCountPair struct {
+	value float64
+	count uint64
+}
+
+// percentileScale converts a float64 into the int64 axis percentileHistogram
+// buckets over, without losing the ordering the histogram relies on.
+const percentileScale = 1e6
+
+// toHistogramAxis scales value and saturates at the int64 bounds rather than
+// converting an out-of-range float straight to int64, which is unspecified
+// behavior in Go. Number properties routinely carry values whose magnitude
+// exceeds ~9.2e12 (epoch-style numbers, large counts, financial values),
+// which scale past the int64 range, so without this guard Percentile() could silently return garbage
+// for such columns.
+func toHistogramAxis(value float64) int64 {
+	scaled := math.Round(value * percentileScale)
+	switch {
+	case scaled >= float64(math.MaxInt64):
+		return math.MaxInt64
+	case scaled <= float64(math.MinInt64):
+		return math.MinInt64
+	default:
+		return int64(scaled)
+	}
+}
+
+func fromHistogramAxis(value int64) float64 {
+	return float64(value) / percentileScale
+}
+
+func (a *numericAggregator) AddNumber(value float64) {
+	a.count++
+	if value < a.min {
+		a.min = value
+	}
+	if value > a.max {
+		a.max = value
+	}
+
+	count := a.valueCounter[value]
+	count++
+	a.valueCounter[value] = count
+
+	a.hist.Add(toHistogramAxis(value), 1)
+}
+
+func (a *numericAggregator) addRow(value float64, count uint64) {
+	if count == 0 {
+		// skip
+		return
+	}
+
+	a.count += count
+	if value < a.min {
+		a.min = value
+	}
+	if value > a.max {
+		a.max = value
+	}
+
+	if count > a.maxCount {
+		a.maxCount = count
+		a.mode = value
+	}
+
+	a.pairs = append(a.pairs, numberCountPair{value: value, count: count})
+	a.hist.Add(toHistogramAxis(value), count)
+}
+
+func (a *numericAggregator) Max() float64 {
+	return a.max
+}
+
+func (a *numericAggregator) Min() float64 {
+	return a.min
+}
+
+// Mode does not require preparation if built from rows. If built from
+// individual objects, the caller must go through prepareFor() first (which
+// addNumericalAggregations already does) so buildPairsFromCounts() has run.
+func (a *numericAggregator) Mode() float64 {
+	return a.mode
+}
+
+func (a *numericAggregator) Count() int64 {
+	return int64(a.count)
+}
+
+// Median does not require preparation if built from rows. If built from
+// individual objects, the caller must go through prepareFor() first (which
+// addNumericalAggregations already does) so buildPairsFromCounts() has run.
+func (a *numericAggregator) Median() float64 {
+	var index uint64
+	if a.count%2 == 0 {
+		index = a.count / 2
+	} else {
+		index = a.count/2 + 1
+	}
+
+	var median float64
+	for _, pair := range a.pairs {
+		if index <= pair.count {
+			median = pair.value
+			break
+		}
+		index -= pair.count
+	}
+
+	return median
+}
+
+// Percentile returns the value at quantile q (0..1) without ever
+// materializing the individual pairs, the same way dateAggregator.Percentile
+// does.
+func (a *numericAggregator) Percentile(q float64) float64 {
+	return fromHistogramAxis(a.hist.Quantile(a.count, q))
+}
+
+// prepareFor lazily materializes and sorts a.pairs, but only when the
+// requested aggregators actually need ordering (Median, and Mode if it was
+// built from individual objects rather than rows). Min/Max/Count/Percentile
+// stay O(1) per row even on result sets with millions of distinct values,
+// since they never trigger the sort below.
+func (a *numericAggregator) prepareFor(aggs []aggregation.Aggregator) {
+	if a.prepared {
+		return
+	}
+
+	if len(a.valueCounter) == 0 {
+		// built from rows: Mode/maxCount were already tracked incrementally in
+		// addRow, and pairs (if any) arrive pre-sorted from the inverted index
+		a.prepared = true
+		return
+	}
+
+	for _, aProp := range aggs {
+		if aProp == aggregation.MedianAggregator || aProp == aggregation.ModeAggregator {
+			a.buildPairsFromCounts()
+			break
+		}
+	}
+
+	a.prepared = true
+}
+
+// buildPairsFromCounts turns the value counter into a sorted list, as well
+// as identifying the mode.
+func (a *numericAggregator) buildPairsFromCounts() {
+	for value, count := range a.valueCounter {
+		if count > a.maxCount {
+			a.maxCount = count
+			a.mode = value
+		}
+		a.pairs = append(a.pairs, numberCountPair{value: value, count: count})
+	}
+
+	sort.Slice(a.pairs, func(x, y int) bool {
+		return a.pairs[x].value < a.pairs[y].value
+	})
+}
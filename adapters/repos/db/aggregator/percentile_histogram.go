@@ -0,0 +1,162 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package aggregator
+
+import (
+	"math"
+	"sort"
+)
+
+// defaultHistogramSchema controls the bucket resolution, matching
+// Prometheus-style native histograms: base = 2^(2^-schema). A higher schema
+// gives narrower buckets (better precision) at the cost of more buckets.
+const defaultHistogramSchema = 3
+
+// percentileHistogram is a sparse, log-linear bucketing scheme over a
+// signed int64 axis (typically epochNano for dates, or the value scaled by
+// percentileScale for numbers). Instead of storing every distinct value, we only ever keep one
+// counter per bucket, so memory is O(number of buckets spanned) rather than
+// O(distinct values) - important for aggregating over millions of rows.
+//
+// Buckets are built over value-baseline rather than the raw value itself.
+// For dates, bucketing raw epochNano would peg resolution to distance from
+// 1970 instead of to the data's actual spread: near present-day timestamps
+// a single bucket already spans several years, so a column full of
+// same-year dates would collapse into one or two buckets and Quantile
+// would interpolate across a multi-year span. baseline is fixed to the
+// first value ever added, so bucket width instead tracks the magnitude of
+// the data itself.
+type percentileHistogram struct {
+	schema      int
+	base        float64
+	baseline    int64
+	hasBaseline bool
+	zeroCount   uint64
+	posBuckets  map[int]uint64
+	negBuckets  map[int]uint64
+}
+
+func newPercentileHistogram() *percentileHistogram {
+	return newPercentileHistogramWithSchema(defaultHistogramSchema)
+}
+
+func newPercentileHistogramWithSchema(schema int) *percentileHistogram {
+	return &percentileHistogram{
+		schema:     schema,
+		base:       math.Pow(2, math.Pow(2, -float64(schema))),
+		posBuckets: map[int]uint64{},
+		negBuckets: map[int]uint64{},
+	}
+}
+
+// bucketIndex returns ceil(log(|delta|) / log(base)), where delta is a value
+// already offset by baseline. The index carries no sign: Add keeps positive
+// and negative deltas in separate maps instead. The bucket with index i
+// covers (base^(i-1), base^i] for positive deltas, and the mirror image for
+// negative ones.
+func (h *percentileHistogram) bucketIndex(delta int64) int {
+	return int(math.Ceil(math.Log(math.Abs(float64(delta))) / math.Log(h.base)))
+}
+
+// Add records count occurrences of value, touching exactly one bucket. The
+// first call to Add fixes baseline so every subsequent value is bucketed
+// relative to it, keeping bucket resolution tied to the data's own spread.
+func (h *percentileHistogram) Add(value int64, count uint64) {
+	if !h.hasBaseline {
+		h.baseline = value
+		h.hasBaseline = true
+	}
+
+	delta := value - h.baseline
+	switch {
+	case delta == 0:
+		h.zeroCount += count
+	case delta > 0:
+		h.posBuckets[h.bucketIndex(delta)] += count
+	default:
+		h.negBuckets[h.bucketIndex(delta)] += count
+	}
+}
+
+func (h *percentileHistogram) bucketBounds(idx int, negative bool) (lower, upper float64) {
+	upperAbs := math.Pow(h.base, float64(idx))
+	lowerAbs := math.Pow(h.base, float64(idx-1))
+	if negative {
+		return -upperAbs, -lowerAbs
+	}
+	return lowerAbs, upperAbs
+}
+
+// Quantile walks the buckets in sorted order accumulating counts until it
+// passes total*q, then linearly interpolates within that bucket and shifts
+// the result back onto the original value axis by adding baseline. count
+// must be the total number of observations added to the histogram.
+func (h *percentileHistogram) Quantile(count uint64, q float64) int64 {
+	if count == 0 {
+		return h.baseline
+	}
+
+	target := q * float64(count)
+	var cumulative float64
+
+	negIdxs := bucketKeys(h.negBuckets)
+	// negative bucket indices are magnitudes (bucketIndex works off
+	// math.Abs), so the largest index is the most negative value - sorting
+	// descending walks from the minimum up toward zero, which is the order
+	// accumulation needs to start from.
+	sort.Sort(sort.Reverse(sort.IntSlice(negIdxs)))
+
+	for _, idx := range negIdxs {
+		c := float64(h.negBuckets[idx])
+		if cumulative+c >= target {
+			lower, upper := h.bucketBounds(idx, true)
+			return h.baseline + interpolate(lower, upper, (target-cumulative)/c)
+		}
+		cumulative += c
+	}
+
+	if cumulative+float64(h.zeroCount) >= target {
+		return h.baseline
+	}
+	cumulative += float64(h.zeroCount)
+
+	posIdxs := bucketKeys(h.posBuckets)
+	sort.Ints(posIdxs)
+
+	for _, idx := range posIdxs {
+		c := float64(h.posBuckets[idx])
+		if cumulative+c >= target {
+			lower, upper := h.bucketBounds(idx, false)
+			return h.baseline + interpolate(lower, upper, (target-cumulative)/c)
+		}
+		cumulative += c
+	}
+
+	// q was out of [0, 1] range or rounding pushed us past the last bucket
+	if len(posIdxs) > 0 {
+		_, upper := h.bucketBounds(posIdxs[len(posIdxs)-1], false)
+		return h.baseline + int64(upper)
+	}
+	return h.baseline
+}
+
+func interpolate(lower, upper, frac float64) int64 {
+	return int64(lower + frac*(upper-lower))
+}
+
+func bucketKeys(buckets map[int]uint64) []int {
+	keys := make([]int, 0, len(buckets))
+	for k := range buckets {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -12,53 +12,75 @@
 package visited
 
 // ListSet is a reusable list with very efficient resets. Inspired by the C++
-// implementation in hnswlib it can be reset with zero memrory writes in the
-// array by moving the match target instead of altering the list. Only after a
-// version overflow do we need to actually reset
+// implementation in hnswlib it can be reset with zero memory writes in the
+// array by moving the match target instead of altering the list. Only after
+// a marker overflow do we need to actually reset.
 //
-// The new implemtation uses a slice where the first element is reserved for the marker.
-// This allow us to use ListSet as a value (i.e. no pointer is required)
-// The marker (i.e. set[0]) allows for reusing the same list without having to zero all elements on each list reset.
-// Resetting the list takes place once the marker (i.e. set[0]) overflows
+// The marker is a uint32, so in practice a ListSet drawn from a Pool and
+// reset once per query can serve billions of queries before it ever has to
+// pay for a full zero-fill - unlike a uint8 marker, which would overflow
+// (and force a full reset) every 255 resets.
 type ListSet struct {
-	set []uint8 // set[0] is reserved for the marker (version)
+	marker uint32
+	set    []uint32 // set[node] holds the marker value at which node was last visited
 }
 
-//  Len returns the number of elements in the list.
-func (l ListSet) Len() uint64 { return uint64(len(l.set)) - 1 }
+// Len returns the number of elements the list currently has capacity for.
+// This is the full backing slice length: unlike the old set[0]-as-marker
+// scheme, where element 0 was reserved and Len() reported len(set)-1, the
+// marker now lives in its own field, so every slot in set is usable capacity.
+// Any caller that hard-coded the old off-by-one needs to be re-checked.
+func (l ListSet) Len() uint64 { return uint64(len(l.set)) }
 
-// Free allocated slice. This list should not be resuable after this call.
+// Free allocated slice. This list should not be reusable after this call.
 func (l *ListSet) Free() { l.set = nil }
 
-// NewList creates a new list. It allocates memory for elements and marker
+// NewList creates a new list with room for size elements.
 func NewList(size int) ListSet {
-	set := make([]uint8, size+1)
-	set[0] = 1 // the marker starts always by 1 since on reset all element are set to 0
-	return ListSet{set: set}
+	return ListSet{
+		marker: 1, // the marker starts always at 1 since on reset all elements are set to 0
+		set:    make([]uint32, size),
+	}
+}
+
+// Grow ensures the list has room for at least n elements, resizing the
+// backing slice up front. Callers that know the upper bound (e.g. the
+// current node count of the graph they're about to search) should call this
+// once instead of relying on the per-Visit resize path.
+func (l *ListSet) Grow(n uint64) {
+	if n <= l.Len() {
+		return
+	}
+
+	newset := make([]uint32, n)
+	copy(newset, l.set)
+	l.set = newset
 }
 
-// Visit sets element at node to the marker value
+// Visit sets element at node to the marker value.
 func (l *ListSet) Visit(node uint64) {
 	if node >= l.Len() { // resize
-		newset := make([]uint8, node+1024)
+		newset := make([]uint32, node+1024)
 		copy(newset, l.set)
 		l.set = newset
 	}
-	l.set[node+1] = l.set[0]
+	l.set[node] = l.marker
 }
 
-// Visited checks if l contains the specified node
+// Visited checks if l contains the specified node.
 func (l *ListSet) Visited(node uint64) bool {
-	return node < l.Len() && l.set[node+1] == l.set[0]
+	return node < l.Len() && l.set[node] == l.marker
 }
 
-// Reset list only in case of an overflow.
+// Reset advances the marker so all previously visited nodes read as
+// unvisited again, without touching the backing slice. Only in the rare case
+// of a marker overflow do we fall back to a full zero-fill.
 func (l *ListSet) Reset() {
-	l.set[0]++
-	if l.set[0] == 0 { // if overflowed
+	l.marker++
+	if l.marker == 0 { // overflowed
 		for i := range l.set {
 			l.set[i] = 0
 		}
-		l.set[0] = 1 // restart counting
+		l.marker = 1 // restart counting
 	}
 }
@@ -0,0 +1,53 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package visited
+
+import "sync"
+
+// Pool hands out ListSets sized to a graph's current node count and
+// recycles them once a search is done with them. Reusing ListSets this way
+// avoids allocating (and zeroing) a fresh backing slice on every
+// Search/SearchByVector call, which matters for long-running query workers
+// that call Reset() many times over the graph's lifetime.
+type Pool struct {
+	pool sync.Pool
+}
+
+// NewPool creates a Pool whose ListSets start out with room for size
+// elements. size should be a reasonable estimate of the graph's node count
+// at the time the pool is created; individual Gets still grow the list as
+// needed.
+func NewPool(size int) *Pool {
+	return &Pool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				l := NewList(size)
+				return &l
+			},
+		},
+	}
+}
+
+// Get returns a ListSet grown to at least size elements and reset, ready to
+// be used for a single search. Callers must return it with Put once done,
+// typically via defer.
+func (p *Pool) Get(size uint64) *ListSet {
+	l := p.pool.Get().(*ListSet)
+	l.Grow(size)
+	l.Reset()
+	return l
+}
+
+// Put returns a ListSet to the pool for reuse.
+func (p *Pool) Put(l *ListSet) {
+	p.pool.Put(l)
+}
@@ -0,0 +1,56 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package aggregation
+
+import "fmt"
+
+// Aggregator identifies which statistic a GraphQL/REST Aggregate request
+// wants computed for a property.
+type Aggregator string
+
+const (
+	MinimumAggregator    Aggregator = "minimum"
+	MaximumAggregator    Aggregator = "maximum"
+	ModeAggregator       Aggregator = "mode"
+	MedianAggregator     Aggregator = "median"
+	CountAggregator      Aggregator = "count"
+	PercentileAggregator Aggregator = "percentile"
+)
+
+func (a Aggregator) String() string {
+	return string(a)
+}
+
+// ParseAggregatorProp maps the aggregator name as it appears in request
+// params (GraphQL field name, REST query param, ...) onto its Aggregator
+// value.
+func ParseAggregatorProp(name string) (Aggregator, error) {
+	switch a := Aggregator(name); a {
+	case MinimumAggregator, MaximumAggregator, ModeAggregator,
+		MedianAggregator, CountAggregator, PercentileAggregator:
+		return a, nil
+	default:
+		return "", fmt.Errorf("unrecognized aggregator %q", name)
+	}
+}
+
+// Property carries both the requested aggregators and their computed
+// results for a single schema property.
+type Property struct {
+	Name                  string
+	DateAggregations      map[string]interface{}
+	NumericalAggregations map[string]interface{}
+
+	// Percentiles holds the quantiles (0..1) requested via
+	// PercentileAggregator, e.g. [0.5, 0.9, 0.99].
+	Percentiles []float64
+}
@@ -84,6 +84,149 @@ func (m *Manager) DeleteObjectReference(
 	return nil
 }
 
+// DeleteObjectReferences deletes multiple references in one call. Inputs
+// targeting the same (Class, ID) are grouped so that object is fetched once
+// and rewritten at most once, no matter how many of its references are being
+// deleted - unlike calling DeleteObjectReference N times, which pays for N
+// object fetches, N schema locks and N object rewrites. Errors are reported
+// per input so partial failures within a batch are visible to the caller.
+//
+// Grouping keys on Class too, not just ID: a Weaviate ID is only unique
+// within its class, so two different-class inputs sharing an ID are two
+// different objects, and fetching one under the other's class would apply
+// its deletions to the wrong object entirely. Inputs using the deprecated
+// Class-less form are grouped by ID alone (class is unknown until fetched),
+// so they cost one fetch per distinct ID, separate from any group for the
+// same ID given with its class.
+func (m *Manager) DeleteObjectReferences(
+	ctx context.Context,
+	principal *models.Principal,
+	inputs []DeleteReferenceInput,
+) []*Error {
+	errs := make([]*Error, len(inputs))
+
+	type objectKey struct {
+		class string
+		id    strfmt.UUID
+	}
+
+	groups := map[objectKey][]int{}
+	var order []objectKey
+	for i, input := range inputs {
+		key := objectKey{class: input.Class, id: input.ID}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	unlock, err := m.locks.LockSchema()
+	if err != nil {
+		lockErr := &Error{"cannot lock", StatusInternalServerError, err}
+		for i := range errs {
+			errs[i] = lockErr
+		}
+		return errs
+	}
+	defer unlock()
+
+	for _, key := range order {
+		idxs := groups[key]
+		m.deleteObjectReferencesGroup(ctx, principal, inputs, idxs, errs)
+	}
+
+	return errs
+}
+
+// deleteObjectReferencesGroup handles every input in idxs that targets the
+// same underlying object: it fetches the object once, applies all requested
+// removeReference mutations in memory, then issues a single PutObject.
+func (m *Manager) deleteObjectReferencesGroup(
+	ctx context.Context,
+	principal *models.Principal,
+	inputs []DeleteReferenceInput,
+	idxs []int,
+	errs []*Error,
+) {
+	first := inputs[idxs[0]]
+	deprecatedEndpoint := first.Class == ""
+
+	res, err := m.getObjectFromRepo(ctx, first.Class, first.ID, additional.Properties{})
+	if err != nil {
+		status := StatusInternalServerError
+		errnf := ErrNotFound{}
+		if errors.As(err, &errnf) {
+			status = StatusNotFound
+		}
+		setAll(errs, idxs, &Error{"source object", status, err})
+		return
+	}
+
+	path := fmt.Sprintf("objects/%s/%s", res.ClassName, first.ID)
+	if err := m.authorizer.Authorize(principal, "update", path); err != nil {
+		setAll(errs, idxs, &Error{path, StatusForbidden, err})
+		return
+	}
+
+	obj := res.Object()
+
+	// group the validated inputs by property so that a property carrying many
+	// deletions is rewritten in a single removeReferences call instead of one
+	// per input - that's what makes the indexed path in removeReferences
+	// actually pay off for a batch.
+	byProperty := map[string][]int{}
+	for _, i := range idxs {
+		input := &inputs[i]
+		input.Class = res.ClassName
+
+		if err := input.validate(ctx, principal, m.schemaManager); err != nil {
+			if deprecatedEndpoint { // for backward comp reasons
+				errs[i] = &Error{"bad inputs deprecated", StatusNotFound, err}
+			} else {
+				errs[i] = &Error{"bad inputs", StatusBadRequest, err}
+			}
+			continue
+		}
+
+		byProperty[input.Property] = append(byProperty[input.Property], i)
+	}
+
+	changed := false
+	for prop, propIdxs := range byProperty {
+		targets := make([]*models.SingleRef, len(propIdxs))
+		for j, i := range propIdxs {
+			targets[j] = &inputs[i].Reference
+		}
+
+		removed, errmsg := removeReferencesFromObject(obj, prop, targets)
+		if errmsg != "" {
+			setAll(errs, propIdxs, &Error{errmsg, StatusInternalServerError, nil})
+			continue
+		}
+		changed = changed || removed > 0
+	}
+
+	if !changed {
+		return
+	}
+
+	obj.LastUpdateTimeUnix = m.timeSource.Now()
+	if err := m.vectorRepo.PutObject(ctx, obj, res.Vector); err != nil {
+		putErr := &Error{"repo.putobject", StatusInternalServerError, err}
+		for _, i := range idxs {
+			if errs[i] == nil {
+				errs[i] = putErr
+			}
+		}
+	}
+}
+
+func setAll(errs []*Error, idxs []int, err *Error) {
+	for _, i := range idxs {
+		errs[i] = err
+	}
+}
+
 func (req *DeleteReferenceInput) validate(
 	ctx context.Context,
 	principal *models.Principal,
@@ -103,24 +246,122 @@ func (req *DeleteReferenceInput) validate(
 // removeReference removes ref from object obj with property prop.
 // It returns ok (removal took place) and an error message
 func removeReference(obj *models.Object, prop string, ref *models.SingleRef) (ok bool, errmsg string) {
+	removed, errmsg := removeReferencesFromObject(obj, prop, []*models.SingleRef{ref})
+	return removed > 0, errmsg
+}
+
+// removeReferencesFromObject removes every ref in targets from obj's
+// property prop in a single pass, returning how many were actually removed.
+func removeReferencesFromObject(obj *models.Object, prop string, targets []*models.SingleRef) (removed int, errmsg string) {
 	properties := obj.Properties.(map[string]interface{})
 	if properties == nil || properties[prop] == nil {
-		return false, ""
+		return 0, ""
 	}
 
 	refs, ok := properties[prop].(models.MultipleRef)
 	if !ok {
-		return false, "source list is not well formed"
+		return 0, "source list is not well formed"
 	}
 
+	newrefs, removed := removeReferences(refs, targets)
+	properties[prop] = newrefs
+	return removed, ""
+}
+
+// wideReferenceThreshold is the point above which building a beacon index
+// pays for itself over a linear scan per target. Below it, the bookkeeping
+// of allocating and populating a map costs more than just scanning.
+const wideReferenceThreshold = 64
+
+// removeReferences removes, for each of targets, one ref in refs with a
+// matching beacon (the first not yet removed), compacting the slice in a
+// single pass. It returns the compacted
+// slice and how many refs were actually removed.
+//
+// "tag" or "mentions" style properties can carry thousands of refs, and a
+// plain scan-per-target is O(n*k) - painful once the batch endpoint above
+// wants to drop many refs from the same property at once. Above
+// wideReferenceThreshold this instead builds a transient beacon -> index map
+// so every target lookup is O(1), for O(n+k) overall.
+func removeReferences(refs models.MultipleRef, targets []*models.SingleRef) (models.MultipleRef, int) {
+	if len(refs) == 0 || len(targets) == 0 {
+		return refs, 0
+	}
+
+	if len(refs) < wideReferenceThreshold {
+		return removeReferencesLinear(refs, targets)
+	}
+
+	return removeReferencesIndexed(refs, targets)
+}
+
+// removeReferencesLinear removes at most one occurrence per target, mirroring
+// the original single-target removeRef behavior (a duplicated beacon in refs
+// is only ever caused by an upstream bug, in which case one delete call
+// clearing one instance at a time is the least surprising behavior).
+func removeReferencesLinear(refs models.MultipleRef, targets []*models.SingleRef) (models.MultipleRef, int) {
+	consumed := make([]bool, len(targets))
 	newrefs := make(models.MultipleRef, 0, len(refs))
+	removed := 0
+
 	for _, r := range refs {
-		if r.Beacon != ref.Beacon {
+		matched := false
+		for ti, t := range targets {
+			if !consumed[ti] && r.Beacon == t.Beacon {
+				consumed[ti] = true
+				matched = true
+				removed++
+				break
+			}
+		}
+		if !matched {
 			newrefs = append(newrefs, r)
 		}
 	}
-	properties[prop] = newrefs
-	return len(refs) != len(newrefs), ""
+
+	return newrefs, removed
+}
+
+// removeReferencesIndexed removes targets using a beacon -> positions index
+// so each target is resolved in O(1), then compacts refs in a single pass
+// using the SliceTricks delete idiom
+// (https://github.com/golang/go/wiki/SliceTricks#delete). Like
+// removeReferencesLinear, a target only ever consumes one ref occurrence: a
+// beacon repeated n times in targets removes the first n occurrences of that
+// beacon in refs, matching removeReferencesLinear's consumed[ti] bookkeeping
+// exactly regardless of which path a given refs length takes.
+func removeReferencesIndexed(refs models.MultipleRef, targets []*models.SingleRef) (models.MultipleRef, int) {
+	positions := make(map[strfmt.URI][]int, len(refs))
+	for i, r := range refs {
+		positions[r.Beacon] = append(positions[r.Beacon], i)
+	}
+
+	consumed := make(map[strfmt.URI]int, len(targets))
+	toRemove := make(map[int]struct{}, len(targets))
+	for _, t := range targets {
+		pos := positions[t.Beacon]
+		next := consumed[t.Beacon]
+		if next < len(pos) {
+			toRemove[pos[next]] = struct{}{}
+			consumed[t.Beacon] = next + 1
+		}
+	}
+	if len(toRemove) == 0 {
+		return refs, 0
+	}
+
+	newrefs := refs[:0]
+	for i, r := range refs {
+		if _, ok := toRemove[i]; ok {
+			continue
+		}
+		newrefs = append(newrefs, r)
+	}
+	for i := len(newrefs); i < len(refs); i++ {
+		refs[i] = nil // avoid memory leaks, see https://github.com/golang/go/wiki/SliceTricks#delete
+	}
+
+	return newrefs, len(toRemove)
 }
 
 // DeleteObjectReference from connected DB
@@ -198,19 +439,6 @@ func (m *Manager) removeReferenceFromClassProps(props interface{}, propertyName
 }
 
 func removeRef(refs models.MultipleRef, property *models.SingleRef) models.MultipleRef {
-	// Remove if this reference is found.
-	for i, currentRef := range refs {
-		if currentRef.Beacon != property.Beacon {
-			continue
-		}
-
-		// remove this one without memory leaks, see
-		// https://github.com/golang/go/wiki/SliceTricks#delete
-		copy(refs[i:], refs[i+1:])
-		refs[len(refs)-1] = nil // or the zero value of T
-		refs = refs[:len(refs)-1]
-		break // we can only remove one at the same time, so break the loop.
-	}
-
-	return refs
+	newrefs, _ := removeReferences(refs, []*models.SingleRef{property})
+	return newrefs
 }